@@ -0,0 +1,57 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BackendFactory 根据Options构造一个Logger，用于接入非zap的日志实现
+type BackendFactory func(opts *Options) Logger
+
+// zapBackendName 是内建的默认backend，对应New构造的*zapLogger，
+// 与其余backend一样通过RegisterBackend注册，NewBackend不再对它特殊处理
+const zapBackendName = "zap"
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]BackendFactory{}
+)
+
+func init() {
+	RegisterBackend(zapBackendName, func(opts *Options) Logger { return New(opts) })
+}
+
+// RegisterBackend 注册一个名为name的日志backend，应用可以在init阶段调用，
+// 随后通过Options.Backend指定该名字、并用NewBackend构造出对应的Logger，
+// 例如backend/slog、backend/logrus、backend/klog等子包都会在自己的init函数里调用本函数完成注册
+func RegisterBackend(name string, factory BackendFactory) {
+	if name == "" || factory == nil {
+		return
+	}
+
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = factory
+}
+
+// NewBackend 根据opts.Backend选择具体实现构造Logger，
+// Backend为空时退化为内建的"zap"，其余取值须先由对应子包通过RegisterBackend注册
+func NewBackend(opts *Options) Logger {
+	if opts == nil {
+		opts = NewOptions()
+	}
+
+	name := opts.Backend
+	if name == "" {
+		name = zapBackendName
+	}
+
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		panic(fmt.Sprintf("log: backend %q is not registered, did you forget to import it?", name))
+	}
+
+	return factory(opts)
+}
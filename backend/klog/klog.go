@@ -0,0 +1,167 @@
+// Package klog 把log.Logger接口适配到k8s.io/klog/v2，
+// 供运行在Kubernetes控制器/webhook里、已经统一用klog打日志的业务通过
+// Options.Backend="klog"选用
+package klog
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/klog/v2"
+
+	log "github.com/liumingzhuo/logz"
+)
+
+func init() {
+	log.RegisterBackend("klog", newLogger)
+}
+
+// logger 用klog.Verbose封装的V(level)实现log.Logger，
+// kv是WithValue/WithName逐层累积下来的固定字段，每次调用时拼到keysAndValues前面
+type logger struct {
+	v  klog.Verbose
+	kv []interface{}
+}
+
+func newLogger(opts *log.Options) log.Logger {
+	if opts == nil {
+		opts = log.NewOptions()
+	}
+
+	return &logger{v: klog.V(0)}
+}
+
+func (lg *logger) Info(msg string, fields ...log.Field) {
+	lg.v.InfoS(msg, lg.kvFields(fields...)...)
+}
+func (lg *logger) Infof(template string, args ...interface{}) { lg.v.Infof(template, args...) }
+func (lg *logger) Infow(msg string, keysAndValues ...interface{}) {
+	lg.v.InfoS(msg, lg.kvArgs(keysAndValues...)...)
+}
+func (lg *logger) Enable() bool { return lg.v.Enabled() }
+
+// Debug klog没有独立的debug级别，统一复用receiver自己的verbosity(lg.v)
+func (lg *logger) Debug(msg string, fields ...log.Field) {
+	lg.v.InfoS(msg, lg.kvFields(fields...)...)
+}
+func (lg *logger) Debugf(template string, args ...interface{}) { lg.v.Infof(template, args...) }
+func (lg *logger) Debugw(msg string, keysAndValues ...interface{}) {
+	lg.v.InfoS(msg, lg.kvArgs(keysAndValues...)...)
+}
+
+func (lg *logger) Warn(msg string, fields ...log.Field) {
+	klog.Warning(withKV(msg, lg.kvFields(fields...)...))
+}
+func (lg *logger) Warnf(template string, args ...interface{}) { klog.Warningf(template, args...) }
+func (lg *logger) Warnw(msg string, keysAndValues ...interface{}) {
+	klog.Warning(withKV(msg, lg.kvArgs(keysAndValues...)...))
+}
+
+func (lg *logger) Error(msg string, fields ...log.Field) {
+	klog.ErrorS(fmt.Errorf("%s", msg), msg, lg.kvFields(fields...)...)
+}
+func (lg *logger) Errorf(template string, args ...interface{}) { klog.Errorf(template, args...) }
+func (lg *logger) Errorw(msg string, keysAndValues ...interface{}) {
+	klog.ErrorS(fmt.Errorf("%s", msg), msg, lg.kvArgs(keysAndValues...)...)
+}
+
+func (lg *logger) Panic(msg string, fields ...log.Field) {
+	klog.ErrorS(fmt.Errorf("%s", msg), msg, lg.kvFields(fields...)...)
+	panic(msg)
+}
+func (lg *logger) Panicf(template string, args ...interface{}) {
+	msg := fmt.Sprintf(template, args...)
+	klog.Error(msg)
+	panic(msg)
+}
+func (lg *logger) Panicw(msg string, keysAndValues ...interface{}) {
+	klog.ErrorS(fmt.Errorf("%s", msg), msg, lg.kvArgs(keysAndValues...)...)
+	panic(msg)
+}
+
+func (lg *logger) Fatal(msg string, fields ...log.Field) {
+	klog.ErrorS(fmt.Errorf("%s", msg), msg, lg.kvFields(fields...)...)
+	klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+}
+func (lg *logger) Fatalf(template string, args ...interface{}) { klog.Fatalf(template, args...) }
+func (lg *logger) Fatalw(msg string, keysAndValues ...interface{}) {
+	klog.ErrorS(fmt.Errorf("%s", msg), msg, lg.kvArgs(keysAndValues...)...)
+	klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+}
+
+// V 返回绑定到对应verbosity等级的InfoLogger，level越大代表日志级别越低，与zapLogger.V语义一致
+func (lg *logger) V(level int) log.InfoLogger {
+	return &logger{v: klog.V(klog.Level(level)), kv: lg.kv}
+}
+
+func (lg *logger) Write(p []byte) (int, error) {
+	lg.v.Info(string(p))
+
+	return len(p), nil
+}
+
+func (lg *logger) WithValue(keysAndValues ...interface{}) log.Logger {
+	return &logger{v: lg.v, kv: append(append([]interface{}{}, lg.kv...), keysAndValues...)}
+}
+
+// WithName klog没有zap式的具名logger，这里把name作为固定字段追加
+func (lg *logger) WithName(name string) log.Logger {
+	return lg.WithValue("logger", name).(*logger)
+}
+
+func (lg *logger) WithContext(ctx context.Context) context.Context {
+	return log.ContextWithLogger(ctx, lg)
+}
+
+func (lg *logger) Flush() { klog.Flush() }
+
+// fieldValue 把log.Field(即zap.Field)还原成klog结构化参数能接受的普通值
+func fieldValue(f log.Field) interface{} {
+	switch f.Type {
+	case zapcore.StringType:
+		return f.String
+	case zapcore.BoolType:
+		return f.Integer == 1
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return f.Integer
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return uint64(f.Integer)
+	case zapcore.Float64Type:
+		return math.Float64frombits(uint64(f.Integer))
+	case zapcore.DurationType:
+		return time.Duration(f.Integer)
+	default:
+		return f.Interface
+	}
+}
+
+func fieldsToKV(fields ...log.Field) []interface{} {
+	kv := make([]interface{}, 0, len(fields)*2)
+	for _, f := range fields {
+		kv = append(kv, f.Key, fieldValue(f))
+	}
+
+	return kv
+}
+
+// kvFields/kvArgs把lg.kv（WithValue/WithName逐层累积的固定字段）拼到本次调用的字段前面，
+// 所有级别都要经过这里，否则WithValue绑定的字段只会出现在Info/Infow的日志行里
+func (lg *logger) kvFields(fields ...log.Field) []interface{} {
+	return append(append([]interface{}{}, lg.kv...), fieldsToKV(fields...)...)
+}
+
+func (lg *logger) kvArgs(keysAndValues ...interface{}) []interface{} {
+	return append(append([]interface{}{}, lg.kv...), keysAndValues...)
+}
+
+// withKV 把keysAndValues追加到msg后面，供没有结构化API的klog.Warning使用
+func withKV(msg string, keysAndValues ...interface{}) string {
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		msg += fmt.Sprintf(" %v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+
+	return msg
+}
@@ -0,0 +1,164 @@
+// Package logrus 把log.Logger接口适配到github.com/sirupsen/logrus，
+// 供不想依赖zap、但已经在用logrus的业务通过Options.Backend="logrus"选用
+package logrus
+
+import (
+	"context"
+	"math"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap/zapcore"
+
+	log "github.com/liumingzhuo/logz"
+)
+
+func init() {
+	log.RegisterBackend("logrus", newLogger)
+}
+
+// logger 用*logrus.Entry实现log.Logger，entry中携带逐层WithValue/WithName累积下来的固定字段
+type logger struct {
+	entry *logrus.Entry
+}
+
+func newLogger(opts *log.Options) log.Logger {
+	if opts == nil {
+		opts = log.NewOptions()
+	}
+
+	base := logrus.New()
+	base.SetOutput(os.Stdout)
+	if opts.Format == "json" {
+		base.SetFormatter(&logrus.JSONFormatter{})
+	} else {
+		base.SetFormatter(&logrus.TextFormatter{})
+	}
+
+	level, err := logrus.ParseLevel(opts.Level)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	base.SetLevel(level)
+
+	entry := logrus.NewEntry(base)
+	if opts.Name != "" {
+		entry = entry.WithField("logger", opts.Name)
+	}
+
+	return &logger{entry: entry}
+}
+
+// fieldValue 把log.Field(即zap.Field)还原成logrus.Fields能接受的普通值
+func fieldValue(f log.Field) interface{} {
+	switch f.Type {
+	case zapcore.StringType:
+		return f.String
+	case zapcore.BoolType:
+		return f.Integer == 1
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return f.Integer
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return uint64(f.Integer)
+	case zapcore.Float64Type:
+		return math.Float64frombits(uint64(f.Integer))
+	case zapcore.DurationType:
+		return time.Duration(f.Integer)
+	default:
+		return f.Interface
+	}
+}
+
+func fieldsToLogrusFields(fields ...log.Field) logrus.Fields {
+	lf := make(logrus.Fields, len(fields))
+	for _, f := range fields {
+		lf[f.Key] = fieldValue(f)
+	}
+
+	return lf
+}
+
+func kvToLogrusFields(keysAndValues ...interface{}) logrus.Fields {
+	lf := make(logrus.Fields, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if key, ok := keysAndValues[i].(string); ok {
+			lf[key] = keysAndValues[i+1]
+		}
+	}
+
+	return lf
+}
+
+func (lg *logger) Info(msg string, fields ...log.Field) {
+	lg.entry.WithFields(fieldsToLogrusFields(fields...)).Info(msg)
+}
+func (lg *logger) Infof(template string, args ...interface{}) { lg.entry.Infof(template, args...) }
+func (lg *logger) Infow(msg string, keysAndValues ...interface{}) {
+	lg.entry.WithFields(kvToLogrusFields(keysAndValues...)).Info(msg)
+}
+func (lg *logger) Enable() bool { return lg.entry.Logger.IsLevelEnabled(logrus.InfoLevel) }
+
+func (lg *logger) Debug(msg string, fields ...log.Field) {
+	lg.entry.WithFields(fieldsToLogrusFields(fields...)).Debug(msg)
+}
+func (lg *logger) Debugf(template string, args ...interface{}) { lg.entry.Debugf(template, args...) }
+func (lg *logger) Debugw(msg string, keysAndValues ...interface{}) {
+	lg.entry.WithFields(kvToLogrusFields(keysAndValues...)).Debug(msg)
+}
+
+func (lg *logger) Warn(msg string, fields ...log.Field) {
+	lg.entry.WithFields(fieldsToLogrusFields(fields...)).Warn(msg)
+}
+func (lg *logger) Warnf(template string, args ...interface{}) { lg.entry.Warnf(template, args...) }
+func (lg *logger) Warnw(msg string, keysAndValues ...interface{}) {
+	lg.entry.WithFields(kvToLogrusFields(keysAndValues...)).Warn(msg)
+}
+
+func (lg *logger) Error(msg string, fields ...log.Field) {
+	lg.entry.WithFields(fieldsToLogrusFields(fields...)).Error(msg)
+}
+func (lg *logger) Errorf(template string, args ...interface{}) { lg.entry.Errorf(template, args...) }
+func (lg *logger) Errorw(msg string, keysAndValues ...interface{}) {
+	lg.entry.WithFields(kvToLogrusFields(keysAndValues...)).Error(msg)
+}
+
+func (lg *logger) Panic(msg string, fields ...log.Field) {
+	lg.entry.WithFields(fieldsToLogrusFields(fields...)).Panic(msg)
+}
+func (lg *logger) Panicf(template string, args ...interface{}) { lg.entry.Panicf(template, args...) }
+func (lg *logger) Panicw(msg string, keysAndValues ...interface{}) {
+	lg.entry.WithFields(kvToLogrusFields(keysAndValues...)).Panic(msg)
+}
+
+func (lg *logger) Fatal(msg string, fields ...log.Field) {
+	lg.entry.WithFields(fieldsToLogrusFields(fields...)).Fatal(msg)
+}
+func (lg *logger) Fatalf(template string, args ...interface{}) { lg.entry.Fatalf(template, args...) }
+func (lg *logger) Fatalw(msg string, keysAndValues ...interface{}) {
+	lg.entry.WithFields(kvToLogrusFields(keysAndValues...)).Fatal(msg)
+}
+
+// V logrus没有zap式的自定义verbosity等级，这里退化成始终返回自身，
+// 对level>0的调用不做额外过滤
+func (lg *logger) V(_ int) log.InfoLogger { return lg }
+
+func (lg *logger) Write(p []byte) (int, error) {
+	lg.entry.Info(string(p))
+
+	return len(p), nil
+}
+
+func (lg *logger) WithValue(keysAndValues ...interface{}) log.Logger {
+	return &logger{entry: lg.entry.WithFields(kvToLogrusFields(keysAndValues...))}
+}
+
+func (lg *logger) WithName(name string) log.Logger {
+	return &logger{entry: lg.entry.WithField("logger", name)}
+}
+
+func (lg *logger) WithContext(ctx context.Context) context.Context {
+	return log.ContextWithLogger(ctx, lg)
+}
+
+func (lg *logger) Flush() {}
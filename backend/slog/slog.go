@@ -0,0 +1,160 @@
+// Package slog 把log.Logger接口适配到标准库的log/slog，
+// 供不想依赖zap的业务通过Options.Backend="slog"选用
+package slog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	log "github.com/liumingzhuo/logz"
+)
+
+func init() {
+	log.RegisterBackend("slog", newLogger)
+}
+
+// logger 用*slog.Logger实现log.Logger
+type logger struct {
+	l *slog.Logger
+}
+
+func newLogger(opts *log.Options) log.Logger {
+	if opts == nil {
+		opts = log.NewOptions()
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(opts.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	hopts := &slog.HandlerOptions{AddSource: !opts.DisableCaller, Level: level}
+
+	var handler slog.Handler
+	if opts.Format == "json" {
+		handler = slog.NewJSONHandler(os.Stdout, hopts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, hopts)
+	}
+
+	l := slog.New(handler)
+	if opts.Name != "" {
+		l = l.With("logger", opts.Name)
+	}
+
+	return &logger{l: l}
+}
+
+// fieldToAttr 把log.Field(即zap.Field)转换成slog.Attr，
+// 覆盖常见的标量类型，其余类型退化为slog.Any(f.Interface)
+func fieldToAttr(f log.Field) slog.Attr {
+	switch f.Type {
+	case zapcore.StringType:
+		return slog.String(f.Key, f.String)
+	case zapcore.BoolType:
+		return slog.Bool(f.Key, f.Integer == 1)
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return slog.Int64(f.Key, f.Integer)
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return slog.Uint64(f.Key, uint64(f.Integer))
+	case zapcore.Float64Type:
+		return slog.Float64(f.Key, math.Float64frombits(uint64(f.Integer)))
+	case zapcore.DurationType:
+		return slog.Duration(f.Key, time.Duration(f.Integer))
+	case zapcore.ErrorType:
+		return slog.Any(f.Key, f.Interface)
+	default:
+		return slog.Any(f.Key, f.Interface)
+	}
+}
+
+func fieldsToArgs(fields ...log.Field) []interface{} {
+	args := make([]interface{}, 0, len(fields))
+	for _, f := range fields {
+		args = append(args, fieldToAttr(f))
+	}
+
+	return args
+}
+
+func (lg *logger) Info(msg string, fields ...log.Field)  { lg.l.Info(msg, fieldsToArgs(fields...)...) }
+func (lg *logger) Infof(template string, args ...interface{}) {
+	lg.l.Info(fmt.Sprintf(template, args...))
+}
+func (lg *logger) Infow(msg string, keysAndValues ...interface{}) { lg.l.Info(msg, keysAndValues...) }
+func (lg *logger) Enable() bool                                   { return lg.l.Enabled(context.Background(), slog.LevelInfo) }
+
+func (lg *logger) Debug(msg string, fields ...log.Field) { lg.l.Debug(msg, fieldsToArgs(fields...)...) }
+func (lg *logger) Debugf(template string, args ...interface{}) {
+	lg.l.Debug(fmt.Sprintf(template, args...))
+}
+func (lg *logger) Debugw(msg string, keysAndValues ...interface{}) { lg.l.Debug(msg, keysAndValues...) }
+
+func (lg *logger) Warn(msg string, fields ...log.Field) { lg.l.Warn(msg, fieldsToArgs(fields...)...) }
+func (lg *logger) Warnf(template string, args ...interface{}) {
+	lg.l.Warn(fmt.Sprintf(template, args...))
+}
+func (lg *logger) Warnw(msg string, keysAndValues ...interface{}) { lg.l.Warn(msg, keysAndValues...) }
+
+func (lg *logger) Error(msg string, fields ...log.Field) { lg.l.Error(msg, fieldsToArgs(fields...)...) }
+func (lg *logger) Errorf(template string, args ...interface{}) {
+	lg.l.Error(fmt.Sprintf(template, args...))
+}
+func (lg *logger) Errorw(msg string, keysAndValues ...interface{}) { lg.l.Error(msg, keysAndValues...) }
+
+func (lg *logger) Panic(msg string, fields ...log.Field) {
+	lg.l.Error(msg, fieldsToArgs(fields...)...)
+	panic(msg)
+}
+func (lg *logger) Panicf(template string, args ...interface{}) {
+	msg := fmt.Sprintf(template, args...)
+	lg.l.Error(msg)
+	panic(msg)
+}
+func (lg *logger) Panicw(msg string, keysAndValues ...interface{}) {
+	lg.l.Error(msg, keysAndValues...)
+	panic(msg)
+}
+
+func (lg *logger) Fatal(msg string, fields ...log.Field) {
+	lg.l.Error(msg, fieldsToArgs(fields...)...)
+	os.Exit(1)
+}
+func (lg *logger) Fatalf(template string, args ...interface{}) {
+	lg.l.Error(fmt.Sprintf(template, args...))
+	os.Exit(1)
+}
+func (lg *logger) Fatalw(msg string, keysAndValues ...interface{}) {
+	lg.l.Error(msg, keysAndValues...)
+	os.Exit(1)
+}
+
+// V slog没有zap式的自定义verbosity等级，这里退化成始终返回自身，
+// 对level>0的调用不做额外过滤
+func (lg *logger) V(_ int) log.InfoLogger { return lg }
+
+func (lg *logger) Write(p []byte) (int, error) {
+	lg.l.Info(string(p))
+
+	return len(p), nil
+}
+
+func (lg *logger) WithValue(keysAndValues ...interface{}) log.Logger {
+	return &logger{l: lg.l.With(keysAndValues...)}
+}
+
+func (lg *logger) WithName(name string) log.Logger {
+	return &logger{l: lg.l.With("logger", name)}
+}
+
+func (lg *logger) WithContext(ctx context.Context) context.Context {
+	return log.ContextWithLogger(ctx, lg)
+}
+
+func (lg *logger) Flush() {}
@@ -0,0 +1,243 @@
+package log
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// loggerCtxKey 避免context key冲突的私有类型
+type loggerCtxKey struct{}
+
+// ContextWithLogger 把l存入ctx中，供FromContext取出。
+// 非zap backend（如backend/slog）也应该复用这个函数来实现自己的WithContext，
+// 这样无论底层backend是什么，FromContext都能识别出之前存入的Logger
+func ContextWithLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// WithContext 拷贝一个context，并且设置了日志值
+func (l *zapLogger) WithContext(ctx context.Context) context.Context {
+	return ContextWithLogger(ctx, l)
+}
+
+// FromContext 取出之前通过WithContext设置在context中的Logger，
+// 并自动附加requestID、traceID/spanID、userID、tenant等已注册的业务字段，
+// 如果context中没有设置Logger，则退化为全局std
+func FromContext(ctx context.Context) Logger {
+	var l Logger = std
+	if ctx != nil {
+		if v, ok := ctx.Value(loggerCtxKey{}).(Logger); ok {
+			l = v
+		}
+	}
+
+	if fields := contextFields(ctx); len(fields) > 0 {
+		return l.WithValue(fieldsToKeysAndValues(fields)...)
+	}
+
+	return l
+}
+
+// ContextExtractor 从context中提取业务定制的日志字段。
+// 应用可以通过RegisterContextExtractor在初始化时注册自己的extractor。
+type ContextExtractor func(ctx context.Context) []Field
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   = []ContextExtractor{requestIDExtractor, traceExtractor, userIDExtractor, tenantExtractor}
+)
+
+// RegisterContextExtractor 注册一个自定义的ContextExtractor，
+// 建议在应用初始化阶段调用一次，后续FromContext及Context系列函数都会自动生效
+func RegisterContextExtractor(extractor ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	extractors = append(extractors, extractor)
+}
+
+func contextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	extractorsMu.RLock()
+	fs := make([]ContextExtractor, len(extractors))
+	copy(fs, extractors)
+	extractorsMu.RUnlock()
+
+	var fields []Field
+	for _, extractor := range fs {
+		fields = append(fields, extractor(ctx)...)
+	}
+
+	return fields
+}
+
+// fieldValue 把Field(即zap.Field)还原成WithValue/Infow风格keysAndValues能接受的普通值
+func fieldValue(f Field) interface{} {
+	switch f.Type {
+	case zapcore.StringType:
+		return f.String
+	case zapcore.BoolType:
+		return f.Integer == 1
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return f.Integer
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return uint64(f.Integer)
+	case zapcore.Float64Type:
+		return math.Float64frombits(uint64(f.Integer))
+	case zapcore.DurationType:
+		return time.Duration(f.Integer)
+	default:
+		return f.Interface
+	}
+}
+
+// fieldsToKeysAndValues 把[]Field还原成WithValue/Infow风格的keysAndValues，
+// 供FromContext把ContextExtractor收集到的字段注入到Logger里
+// （Logger接口没有接受[]Field的方法，因此要转换回key/value对）
+func fieldsToKeysAndValues(fields []Field) []interface{} {
+	kv := make([]interface{}, 0, len(fields)*2)
+	for _, f := range fields {
+		kv = append(kv, f.Key, fieldValue(f))
+	}
+
+	return kv
+}
+
+// 内置的well-known字段，通过context.WithValue的方式由业务在链路入口设置
+type (
+	requestIDCtxKey struct{}
+	userIDCtxKey    struct{}
+	tenantCtxKey    struct{}
+)
+
+// WithRequestID 在context中设置请求ID，后续日志会自动携带该字段
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+// WithUserID 在context中设置用户ID，后续日志会自动携带该字段
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDCtxKey{}, userID)
+}
+
+// WithTenant 在context中设置租户标识，后续日志会自动携带该字段
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenant)
+}
+
+func requestIDExtractor(ctx context.Context) []Field {
+	if v, ok := ctx.Value(requestIDCtxKey{}).(string); ok && v != "" {
+		return []Field{String("request_id", v)}
+	}
+
+	return nil
+}
+
+// traceExtractor 从context中读取OpenTelemetry的SpanContext，
+// 自动附加trace_id、span_id，使日志可以与链路追踪关联
+func traceExtractor(ctx context.Context) []Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []Field{String("trace_id", sc.TraceID().String()), String("span_id", sc.SpanID().String())}
+}
+
+func userIDExtractor(ctx context.Context) []Field {
+	if v, ok := ctx.Value(userIDCtxKey{}).(string); ok && v != "" {
+		return []Field{String("user_id", v)}
+	}
+
+	return nil
+}
+
+func tenantExtractor(ctx context.Context) []Field {
+	if v, ok := ctx.Value(tenantCtxKey{}).(string); ok && v != "" {
+		return []Field{String("tenant", v)}
+	}
+
+	return nil
+}
+
+// 以下是context-aware的包级便捷函数，内部都是FromContext(ctx)之后转发给Logger，
+// 与log.go中Info/Infof/Infow等非Context版本一一对应
+
+func DebugContext(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).Debug(msg, fields...)
+}
+
+func DebugfContext(ctx context.Context, template string, args ...interface{}) {
+	FromContext(ctx).Debugf(template, args...)
+}
+
+func DebugwContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	FromContext(ctx).Debugw(msg, keysAndValues...)
+}
+
+func InfoContext(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).Info(msg, fields...)
+}
+
+func InfofContext(ctx context.Context, template string, args ...interface{}) {
+	FromContext(ctx).Infof(template, args...)
+}
+
+func InfowContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	FromContext(ctx).Infow(msg, keysAndValues...)
+}
+
+func WarnContext(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).Warn(msg, fields...)
+}
+
+func WarnfContext(ctx context.Context, template string, args ...interface{}) {
+	FromContext(ctx).Warnf(template, args...)
+}
+
+func WarnwContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	FromContext(ctx).Warnw(msg, keysAndValues...)
+}
+
+func ErrorContext(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).Error(msg, fields...)
+}
+
+func ErrorfContext(ctx context.Context, template string, args ...interface{}) {
+	FromContext(ctx).Errorf(template, args...)
+}
+
+func ErrorwContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	FromContext(ctx).Errorw(msg, keysAndValues...)
+}
+
+func PanicContext(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).Panic(msg, fields...)
+}
+
+func PanicfContext(ctx context.Context, template string, args ...interface{}) {
+	FromContext(ctx).Panicf(template, args...)
+}
+
+func PanicwContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	FromContext(ctx).Panicw(msg, keysAndValues...)
+}
+
+func FatalContext(ctx context.Context, msg string, fields ...Field) {
+	FromContext(ctx).Fatal(msg, fields...)
+}
+
+func FatalfContext(ctx context.Context, template string, args ...interface{}) {
+	FromContext(ctx).Fatalf(template, args...)
+}
+
+func FatalwContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	FromContext(ctx).Fatalw(msg, keysAndValues...)
+}
@@ -0,0 +1,41 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFromContextAppliesRegisteredFieldExtractor(t *testing.T) {
+	RegisterContextExtractor(func(ctx context.Context) []Field {
+		return []Field{String("order_id", "o-1")}
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.log")
+
+	opts := NewOptions()
+	opts.Format = jsonFormat
+	opts.OutputPaths = []string{path}
+
+	l := New(opts)
+	FromContext(l.WithContext(context.Background())).Info("hello")
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(data), &m); err != nil {
+		t.Fatalf("output is not valid json: %v, got: %s", err, data)
+	}
+
+	if m["order_id"] != "o-1" {
+		t.Errorf("expected order_id=o-1 from the registered extractor, got %v", m["order_id"])
+	}
+}
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewErrorLogPathsRouting(t *testing.T) {
+	dir := t.TempDir()
+	appLog := filepath.Join(dir, "app.log")
+	errLog := filepath.Join(dir, "error.log")
+
+	opts := NewOptions()
+	opts.Format = jsonFormat
+	opts.OutputPaths = []string{appLog}
+	opts.ErrorLogPaths = []string{errLog}
+
+	l := New(opts)
+	l.Info("hello from info")
+	l.Error("boom")
+	l.Flush()
+
+	appContent, err := os.ReadFile(appLog)
+	if err != nil {
+		t.Fatalf("read app.log: %v", err)
+	}
+	errContent, err := os.ReadFile(errLog)
+	if err != nil {
+		t.Fatalf("read error.log: %v", err)
+	}
+
+	if !strings.Contains(string(appContent), "hello from info") {
+		t.Errorf("app.log missing info message, got: %s", appContent)
+	}
+	if strings.Contains(string(appContent), "boom") {
+		t.Errorf("app.log should not contain error message, got: %s", appContent)
+	}
+	if !strings.Contains(string(errContent), "boom") {
+		t.Errorf("error.log missing error message, got: %s", errContent)
+	}
+	if strings.Contains(string(errContent), "hello from info") {
+		t.Errorf("error.log should not contain info message, got: %s", errContent)
+	}
+
+	var line map[string]interface{}
+	firstLine := strings.SplitN(string(errContent), "\n", 2)[0]
+	if err := json.Unmarshal([]byte(firstLine), &line); err != nil {
+		t.Fatalf("error.log is not valid json: %v", err)
+	}
+}
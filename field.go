@@ -0,0 +1,21 @@
+package log
+
+import "go.uber.org/zap"
+
+// Field 是zap.Field的别名，使上层业务可以构造结构化字段而无需直接依赖zap
+type Field = zap.Field
+
+// 常用字段构造函数的别名，方便业务方直接使用log包构造Field而不必import zap
+var (
+	Any      = zap.Any
+	String   = zap.String
+	Int      = zap.Int
+	Int32    = zap.Int32
+	Int64    = zap.Int64
+	Uint64   = zap.Uint64
+	Float64  = zap.Float64
+	Bool     = zap.Bool
+	Err      = zap.Error
+	Duration = zap.Duration
+	Time     = zap.Time
+)
@@ -0,0 +1,21 @@
+// Package httpctl 提供把logz的运行时控制能力（目前是动态日志级别）
+// 挂载到HTTP服务上的小工具，方便运维通过curl在线调整
+package httpctl
+
+import "net/http"
+
+// LevelController 是具备动态调级能力的Logger的最小接口，
+// log包的*zapLogger实现了该接口
+type LevelController interface {
+	SetLevel(lvl string) error
+	LevelHandler() http.Handler
+}
+
+// RegisterLevelHandler 把ctrl.LevelHandler()挂载到mux的pattern路径下，
+// 之后即可通过GET查看、PUT修改日志级别，例如：
+//
+//	httpctl.RegisterLevelHandler(mux, "/log/level", log.StdLogger())
+//	curl -XPUT -d '{"level":"debug"}' http://localhost:8080/log/level
+func RegisterLevelHandler(mux *http.ServeMux, pattern string, ctrl LevelController) {
+	mux.Handle(pattern, ctrl.LevelHandler())
+}
@@ -0,0 +1,222 @@
+// Package zap 封装logz默认zap backend的构建细节：encoder配置、error日志分流(Tee)、
+// lumberjack滚动sink、基于dedup_key的限流Core。
+//
+// 本包只依赖go.uber.org/zap相关库及lumberjack，不反向依赖根包github.com/liumingzhuo/logz——
+// 根包的zapLogger需要在包级变量std初始化时就把它eagerly构造出来，如果本包又
+// 依赖根包的Options/Logger等类型，会形成import cycle。因此这里用标量字段的
+// Config对应根包Options中与zap构建相关的部分，根包New负责两者间的转换。
+package zap
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	consoleFormat = "console"
+	jsonFormat    = "json"
+)
+
+// Config 是构造底层*zap.Logger所需的参数，字段与根包Options一一对应
+type Config struct {
+	Level             zapcore.Level
+	Name              string
+	OutputPaths       []string
+	ErrorOutputPaths  []string
+	ErrorLogPaths     []string
+	Format            string
+	ErrorFormat       string
+	EnableColor       bool
+	ErrorEnableColor  bool
+	Development       bool
+	DisableCaller     bool
+	DisableStacktrace bool
+
+	EnableRotate bool
+	MaxSize      int
+	MaxBackups   int
+	MaxAge       int
+	Compress     bool
+	LocalTime    bool
+
+	SamplingEnabled    bool
+	SamplingInitial    int
+	SamplingThereafter int
+	SamplingTick       time.Duration
+
+	RateLimitEnabled bool
+	RateLimitRate    float64
+	RateLimitBurst   int
+	RateLimitMaxKeys int
+}
+
+// Build 根据cfg构造*zap.Logger及其AtomicLevel，根包的New在此基础上包装出zapLogger
+func Build(cfg Config) (*zap.Logger, zap.AtomicLevel, error) {
+	outputPaths := cfg.OutputPaths
+	if cfg.EnableRotate {
+		outputPaths = rotateOutputPaths(outputPaths, cfg)
+	}
+
+	atom := zap.NewAtomicLevelAt(cfg.Level)
+
+	var (
+		l   *zap.Logger
+		err error
+	)
+	if len(cfg.ErrorLogPaths) > 0 {
+		l, err = buildTeeLogger(cfg, outputPaths, atom)
+	} else {
+		l, err = buildSingleLogger(cfg, outputPaths, atom)
+	}
+	if err != nil {
+		return nil, atom, err
+	}
+
+	if cfg.RateLimitEnabled {
+		limiter := getSharedLimiter(rateLimitConfig{
+			Rate:    cfg.RateLimitRate,
+			Burst:   cfg.RateLimitBurst,
+			MaxKeys: cfg.RateLimitMaxKeys,
+		})
+		l = l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newRateLimitedCore(core, limiter)
+		}))
+	}
+
+	return l.Named(cfg.Name), atom, nil
+}
+
+// buildEncoderConfig 根据format/enableColor构造EncoderConfig，
+// info sink与error sink各自的编码格式/颜色可以不同，因此抽成公共函数供两者复用
+func buildEncoderConfig(format string, enableColor bool) zapcore.EncoderConfig {
+	encodeLevel := zapcore.CapitalLevelEncoder
+	if format == consoleFormat && enableColor {
+		encodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+
+	return zapcore.EncoderConfig{
+		MessageKey:     "message",
+		LevelKey:       "level",
+		TimeKey:        "timestamp",
+		NameKey:        "logger",
+		CallerKey:      "	caller",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    encodeLevel,
+		EncodeTime:     timeEncoder,
+		EncodeDuration: milliSecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
+func buildEncoder(format string, enableColor bool) zapcore.Encoder {
+	cfg := buildEncoderConfig(format, enableColor)
+	if format == jsonFormat {
+		return zapcore.NewJSONEncoder(cfg)
+	}
+
+	return zapcore.NewConsoleEncoder(cfg)
+}
+
+// buildSingleLogger 是未配置ErrorLogPaths时的默认构建方式：
+// 所有级别共用loggerConfig.Build生成的单个Core，Level使用传入的atom以支持运行时动态调级
+func buildSingleLogger(cfg Config, outputPaths []string, atom zap.AtomicLevel) (*zap.Logger, error) {
+	var sampling *zap.SamplingConfig
+	if cfg.SamplingEnabled {
+		sampling = &zap.SamplingConfig{
+			Initial:    cfg.SamplingInitial,
+			Thereafter: cfg.SamplingThereafter,
+		}
+	}
+
+	loggerConfig := &zap.Config{
+		Level:             atom,
+		Development:       cfg.Development,
+		DisableStacktrace: cfg.DisableStacktrace,
+		DisableCaller:     cfg.DisableCaller,
+		Sampling:          sampling,
+		Encoding:          cfg.Format,
+		EncoderConfig:     buildEncoderConfig(cfg.Format, cfg.EnableColor),
+		OutputPaths:       outputPaths,
+		ErrorOutputPaths:  cfg.ErrorOutputPaths,
+	}
+
+	return loggerConfig.Build(zap.AddStacktrace(zapcore.PanicLevel), zap.AddCallerSkip(1))
+}
+
+// buildTeeLogger 在配置了ErrorLogPaths时使用：
+// 低于ErrorLevel的日志走outputPaths对应的Core，ErrorLevel及以上走ErrorLogPaths对应的Core，
+// 两个Core各自可以有独立的编码格式/颜色，通过zapcore.NewTee组合成一个Core。
+// 级别判断读取atom.Level()，因此同样支持运行时动态调级
+func buildTeeLogger(cfg Config, outputPaths []string, atom zap.AtomicLevel) (*zap.Logger, error) {
+	errorPaths := cfg.ErrorLogPaths
+	if cfg.EnableRotate {
+		errorPaths = rotateOutputPaths(errorPaths, cfg)
+	}
+
+	infoSink, _, err := zap.Open(outputPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	errorSink, _, err := zap.Open(errorPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	errFormat, errColor := cfg.Format, cfg.EnableColor
+	if cfg.ErrorFormat != "" {
+		errFormat = cfg.ErrorFormat
+	}
+	if cfg.ErrorEnableColor {
+		errColor = true
+	}
+
+	infoEnabler := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		return lvl >= atom.Level() && lvl < zapcore.ErrorLevel
+	})
+	errorEnabler := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		return lvl >= zapcore.ErrorLevel && lvl >= atom.Level()
+	})
+
+	core := zapcore.NewTee(
+		zapcore.NewCore(buildEncoder(cfg.Format, cfg.EnableColor), infoSink, infoEnabler),
+		zapcore.NewCore(buildEncoder(errFormat, errColor), errorSink, errorEnabler),
+	)
+	if cfg.SamplingEnabled {
+		tick := cfg.SamplingTick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		core = zapcore.NewSamplerWithOptions(core, tick, cfg.SamplingInitial, cfg.SamplingThereafter)
+	}
+
+	zapOpts := []zap.Option{zap.AddCallerSkip(1), zap.AddStacktrace(zapcore.PanicLevel)}
+	if !cfg.DisableCaller {
+		zapOpts = append(zapOpts, zap.AddCaller())
+	}
+	if cfg.Development {
+		zapOpts = append(zapOpts, zap.Development())
+	}
+	if len(cfg.ErrorOutputPaths) > 0 {
+		errOutput, _, err := zap.Open(cfg.ErrorOutputPaths...)
+		if err != nil {
+			return nil, err
+		}
+		zapOpts = append(zapOpts, zap.ErrorOutput(errOutput))
+	}
+
+	return zap.New(core, zapOpts...), nil
+}
+
+// timeEncoder 以"2006-01-02 15:04:05.000"的格式编码时间戳
+func timeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString(t.Format("2006-01-02 15:04:05.000"))
+}
+
+// milliSecondsDurationEncoder 以毫秒为单位编码Duration
+func milliSecondsDurationEncoder(d time.Duration, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendFloat64(float64(d) / float64(time.Millisecond))
+}
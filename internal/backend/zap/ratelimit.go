@@ -0,0 +1,221 @@
+package zap
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// dedupKeyField 是业务用来标记"同一类噪声日志"的字段名，
+// 典型用法：log.WithValue("dedup_key", "db-timeout").Errorw(...)
+const dedupKeyField = "dedup_key"
+
+// rateLimitCore 包装另一个zapcore.Core，对带dedup_key字段的日志按key做令牌桶限流，
+// 被限流丢弃的条目会累计计数，下次放行时自动带上repeated=N字段。
+// dedup_key既可以通过Errorw等方法内联传入（出现在Write的fields里），
+// 也可以通过WithValue/log.With预先绑定（这种情况下字段只会出现在With的fields里，
+// 不会再传给Write），因此两处都要检查
+type rateLimitCore struct {
+	zapcore.Core
+	limiter  *keyLimiter
+	dedupKey string
+	hasKey   bool
+}
+
+// newRateLimitedCore 用limiter装饰core；limiter由调用方共享，
+// 因此同一个limiter实例派生出的多个Core（如std及其WithValue/WithName的派生logger）
+// 对同一个dedup_key的限流状态是共享的
+func newRateLimitedCore(core zapcore.Core, limiter *keyLimiter) zapcore.Core {
+	return &rateLimitCore{Core: core, limiter: limiter}
+}
+
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	next := &rateLimitCore{Core: c.Core.With(fields), limiter: c.limiter, dedupKey: c.dedupKey, hasKey: c.hasKey}
+	if key, ok := extractDedupKey(fields); ok {
+		next.dedupKey, next.hasKey = key, true
+	}
+
+	return next
+}
+
+func (c *rateLimitCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+
+	return ce
+}
+
+func (c *rateLimitCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	key, ok := extractDedupKey(fields)
+	if !ok {
+		key, ok = c.dedupKey, c.hasKey
+	}
+	if !ok {
+		return c.writeThrough(entry, fields)
+	}
+
+	allowed, repeated := c.limiter.allow(key)
+	if !allowed {
+		return nil
+	}
+
+	if repeated > 0 {
+		withRepeated := make([]zapcore.Field, len(fields), len(fields)+1)
+		copy(withRepeated, fields)
+		fields = append(withRepeated, zap.Int64("repeated", repeated))
+	}
+
+	return c.writeThrough(entry, fields)
+}
+
+// writeThrough 经由被包装Core自身的Check写出entry，使其中的级别分流(Tee)与采样(Sampler)
+// 依旧生效；直接调用c.Core.Write会把entry写到Tee的每个成员并绕过采样
+func (c *rateLimitCore) writeThrough(entry zapcore.Entry, fields []zapcore.Field) error {
+	if checked := c.Core.Check(entry, nil); checked != nil {
+		checked.Write(fields...)
+	}
+
+	return nil
+}
+
+func extractDedupKey(fields []zapcore.Field) (string, bool) {
+	for _, f := range fields {
+		if f.Key != dedupKeyField {
+			continue
+		}
+		if f.Type == zapcore.StringType {
+			return f.String, true
+		}
+
+		return fmt.Sprint(f.Interface), true
+	}
+
+	return "", false
+}
+
+// keyLimiter 是一个按key独立计数的令牌桶限流器，
+// 同时跟踪的key数量超过maxKeys时按LRU淘汰最久未使用的key
+type keyLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   int
+	maxKeys int
+	ll      *list.List
+	items   map[string]*list.Element
+}
+
+type bucket struct {
+	key      string
+	tokens   float64
+	lastSeen time.Time
+	repeated int64
+}
+
+// newKeyLimiter 创建一个令牌桶限流器：rate为每个key每秒放行的日志条数，
+// burst为桶容量（允许的突发条数），maxKeys为同时跟踪的key上限，<=0表示不限制
+func newKeyLimiter(rate float64, burst int, maxKeys int) *keyLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &keyLimiter{
+		rate:    rate,
+		burst:   burst,
+		maxKeys: maxKeys,
+		ll:      list.New(),
+		items:   make(map[string]*list.Element),
+	}
+}
+
+// allow 判断key对应的日志此刻是否放行；不放行时仅自增该key的repeated计数，
+// 下次放行时把期间累计的repeated数一并返回
+func (l *keyLimiter) allow(key string) (ok bool, repeated int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	el, exists := l.items[key]
+	var b *bucket
+	if exists {
+		l.ll.MoveToFront(el)
+		b = el.Value.(*bucket)
+	} else {
+		b = &bucket{key: key, tokens: float64(l.burst), lastSeen: now}
+		el = l.ll.PushFront(b)
+		l.items[key] = el
+		l.evict()
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.lastSeen = now
+	b.tokens += elapsed * l.rate
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+
+	if b.tokens < 1 {
+		b.repeated++
+
+		return false, 0
+	}
+
+	b.tokens--
+	repeated = b.repeated
+	b.repeated = 0
+
+	return true, repeated
+}
+
+func (l *keyLimiter) evict() {
+	if l.maxKeys <= 0 {
+		return
+	}
+
+	for len(l.items) > l.maxKeys {
+		oldest := l.ll.Back()
+		if oldest == nil {
+			return
+		}
+
+		l.ll.Remove(oldest)
+		delete(l.items, oldest.Value.(*bucket).key)
+	}
+}
+
+// rateLimitConfig 是getSharedLimiter的去重键，对应Config里RateLimit相关的标量字段
+type rateLimitConfig struct {
+	Rate    float64
+	Burst   int
+	MaxKeys int
+}
+
+// limiters 按rateLimitConfig的值去重：用完全相同的限流配置构建的Logger
+// （包括std、以及由同一个Logger派生出的WithValue/WithName实例，它们通过
+// rateLimitCore.With共享同一个*keyLimiter）都会复用同一个keyLimiter实例，
+// 从而共享限流状态；配置不同的Logger各自拥有独立的限流器，互不影响
+var (
+	limitersMu sync.Mutex
+	limiters   = map[rateLimitConfig]*keyLimiter{}
+)
+
+// getSharedLimiter 按cfg去重返回一个keyLimiter：同样的cfg总是拿到同一个实例，
+// 不同的cfg各自独立，不会像只有单个全局实例时那样被先创建的一方悄悄覆盖
+func getSharedLimiter(cfg rateLimitConfig) *keyLimiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	if l, ok := limiters[cfg]; ok {
+		return l
+	}
+
+	l := newKeyLimiter(cfg.Rate, cfg.Burst, cfg.MaxKeys)
+	limiters[cfg] = l
+
+	return l
+}
@@ -0,0 +1,86 @@
+package zap
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestKeyLimiterCoalesces(t *testing.T) {
+	l := newKeyLimiter(1000, 1, 10)
+
+	ok, repeated := l.allow("k")
+	if !ok || repeated != 0 {
+		t.Fatalf("first call should be allowed with repeated=0, got ok=%v repeated=%d", ok, repeated)
+	}
+
+	if ok, _ := l.allow("k"); ok {
+		t.Fatalf("second immediate call should be rate-limited")
+	}
+	if ok, _ := l.allow("k"); ok {
+		t.Fatalf("third immediate call should be rate-limited")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	ok, repeated = l.allow("k")
+	if !ok {
+		t.Fatalf("call after the bucket refills should be allowed")
+	}
+	if repeated != 2 {
+		t.Fatalf("expected repeated=2 (the 2 coalesced calls), got %d", repeated)
+	}
+}
+
+func TestRateLimitCoreHonorsDedupKeyFromWith(t *testing.T) {
+	limiter := newKeyLimiter(1000, 1, 10)
+	base := zapcore.NewNopCore()
+	core := newRateLimitedCore(base, limiter)
+
+	// 模拟log.WithValue("dedup_key", "db-timeout").Errorw(...)：
+	// dedup_key只出现在With的fields里，Write拿到的fields里没有它
+	withKey := core.With([]zapcore.Field{zap.String("dedup_key", "db-timeout")})
+
+	if _, ok := limiter.items["db-timeout"]; ok {
+		t.Fatalf("limiter should not have tracked the key yet")
+	}
+
+	entry := zapcore.Entry{Level: zapcore.ErrorLevel}
+	if err := withKey.Write(entry, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := limiter.items["db-timeout"]; !ok {
+		t.Fatalf("expected dedup_key bound via With to be rate-limited on Write")
+	}
+}
+
+func TestGetSharedLimiterKeyedByOptions(t *testing.T) {
+	a := getSharedLimiter(rateLimitConfig{Rate: 1, Burst: 1, MaxKeys: 10})
+	b := getSharedLimiter(rateLimitConfig{Rate: 1, Burst: 1, MaxKeys: 10})
+	if a != b {
+		t.Fatalf("expected identical rate limit config to share the same limiter instance")
+	}
+
+	c := getSharedLimiter(rateLimitConfig{Rate: 99, Burst: 5, MaxKeys: 20})
+	if a == c {
+		t.Fatalf("expected different rate limit config to get independent limiter instances")
+	}
+}
+
+func TestKeyLimiterLRUEviction(t *testing.T) {
+	l := newKeyLimiter(1, 1, 2)
+
+	l.allow("a")
+	l.allow("b")
+	l.allow("c")
+
+	if _, ok := l.items["a"]; ok {
+		t.Fatalf("expected least-recently-used key %q to be evicted", "a")
+	}
+	if len(l.items) != 2 {
+		t.Fatalf("expected 2 tracked keys after eviction, got %d", len(l.items))
+	}
+}
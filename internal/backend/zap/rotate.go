@@ -0,0 +1,97 @@
+package zap
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// lumberjackScheme 是注册给zap.RegisterSink的自定义URL scheme，
+// OutputPaths中形如"lumberjack:///var/log/app.log?maxsize=100&..."的路径
+// 会被解析成一个可自动滚动的lumberjack.Logger
+const lumberjackScheme = "lumberjack"
+
+func init() {
+	// 同一进程内log包可能被多次初始化（例如测试），重复注册会返回error，忽略即可
+	_ = zap.RegisterSink(lumberjackScheme, newLumberjackSink)
+}
+
+// newLumberjackSink 根据URL中的path与query参数构造一个lumberjack WriteSyncer
+func newLumberjackSink(u *url.URL) (zap.Sink, error) {
+	filename := u.Path
+	if filename == "" {
+		filename = u.Opaque
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("log: lumberjack sink requires a file path, got %q", u.String())
+	}
+
+	lj := &lumberjack.Logger{Filename: filename}
+
+	q := u.Query()
+	if v, err := strconv.Atoi(q.Get("maxsize")); err == nil {
+		lj.MaxSize = v
+	}
+	if v, err := strconv.Atoi(q.Get("maxbackups")); err == nil {
+		lj.MaxBackups = v
+	}
+	if v, err := strconv.Atoi(q.Get("maxage")); err == nil {
+		lj.MaxAge = v
+	}
+	if v, err := strconv.ParseBool(q.Get("compress")); err == nil {
+		lj.Compress = v
+	}
+	if v, err := strconv.ParseBool(q.Get("localtime")); err == nil {
+		lj.LocalTime = v
+	}
+
+	return lumberjackSink{lj}, nil
+}
+
+// lumberjackSink 为*lumberjack.Logger补上zap.Sink要求的io.Closer/zapcore.WriteSyncer接口
+type lumberjackSink struct {
+	*lumberjack.Logger
+}
+
+func (lumberjackSink) Sync() error { return nil }
+
+// rotateOutputPaths 把paths中看起来像本地文件的路径改写成带滚动参数的lumberjack://路径，
+// "stdout"/"stderr"以及已经带有scheme的路径原样返回
+func rotateOutputPaths(paths []string, cfg Config) []string {
+	rotated := make([]string, len(paths))
+	for i, p := range paths {
+		rotated[i] = rotateOutputPath(p, cfg)
+	}
+
+	return rotated
+}
+
+func rotateOutputPath(path string, cfg Config) string {
+	if path == "stdout" || path == "stderr" || strings.Contains(path, "://") {
+		return path
+	}
+	// 相对路径会被URL解析成host而非path，因此先转成绝对路径
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	values := url.Values{}
+	values.Set("maxsize", strconv.Itoa(cfg.MaxSize))
+	values.Set("maxbackups", strconv.Itoa(cfg.MaxBackups))
+	values.Set("maxage", strconv.Itoa(cfg.MaxAge))
+	values.Set("compress", strconv.FormatBool(cfg.Compress))
+	values.Set("localtime", strconv.FormatBool(cfg.LocalTime))
+
+	u := url.URL{
+		Scheme:   lumberjackScheme,
+		Path:     path,
+		RawQuery: values.Encode(),
+	}
+
+	return u.String()
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"sync"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	zapbackend "github.com/liumingzhuo/logz/internal/backend/zap"
 )
 
 // InfoLogger infoLogger 记录非错误日志
@@ -89,9 +92,22 @@ func (l *infoLogger) Infof(template string, args ...interface{}) {
 	}
 }
 
+// fieldsPool 复用Infow等高频调用路径上用到的[]zap.Field，减少逐次分配
+var fieldsPool = sync.Pool{
+	New: func() interface{} {
+		s := make([]zap.Field, 0, 8)
+
+		return &s
+	},
+}
+
 func (l *infoLogger) Infow(msg string, keyAndValues ...interface{}) {
 	if checkEntry := l.log.Check(l.level, msg); checkEntry != nil {
-		checkEntry.Write(handleFields(l.log, keyAndValues)...)
+		fp := fieldsPool.Get().(*[]zap.Field)
+		*fp = appendFields((*fp)[:0], l.log, keyAndValues)
+		checkEntry.Write(*fp...)
+		*fp = (*fp)[:0]
+		fieldsPool.Put(fp)
 	}
 }
 
@@ -99,21 +115,35 @@ func (l *infoLogger) Enable() bool {
 	return true
 }
 
-// handlerFields 将pair对转换成zap.Field数组
+// handleFields 将pair对转换成zap.Field数组，additional会被拼接在结果最前面
 func handleFields(l *zap.Logger, args []interface{}, additional ...zap.Field) []zap.Field {
 	if len(args) == 0 {
 		return additional
 	}
-	fields := make([]zap.Field, len(args)/2+len(additional))
+
+	fields := make([]zap.Field, 0, len(args)/2+len(additional))
+	fields = append(fields, additional...)
+
+	return appendFields(fields, l, args)
+}
+
+// appendFields 把args中的key-value对追加到dst后面并返回，
+// 供handleFields以及Infow的零分配路径共用
+func appendFields(dst []zap.Field, l *zap.Logger, args []interface{}) []zap.Field {
 	for i := 0; i < len(args); {
+		if field, ok := args[i].(zap.Field); ok {
+			l.DPanic("strongly-typed zap.Field passed to logz", zap.Any("zap field", field))
+			break
+		}
 		if _, ok := args[i].(*zap.Field); ok {
-			l.DPanic("Strongly-typed Zap Field pass to logz", zap.Any("zap field", args[i]))
+			l.DPanic("strongly-typed zap.Field passed to logz", zap.Any("zap field", args[i]))
 			break
 		}
 		if i == len(args)-1 {
-			l.DPanic("add number of arguments passed as key-value pairs for logging.", zap.Any("ignored key", args[i]))
+			l.DPanic("odd number of arguments passed as key-value pairs for logging", zap.Any("ignored key", args[i]))
 			break
 		}
+
 		key, value := args[i], args[i+1]
 		keyStr, isString := key.(string)
 		if !isString {
@@ -121,16 +151,19 @@ func handleFields(l *zap.Logger, args []interface{}, additional ...zap.Field) []
 			break
 		}
 
-		fields = append(fields, zap.Any(keyStr, value))
+		dst = append(dst, zap.Any(keyStr, value))
 		i += 2
 	}
 
-	return fields
+	return dst
 }
 
 type zapLogger struct {
 	zapLogger *zap.Logger
 	infoLogger
+
+	// atomicLevel 持有构建core时使用的zap.AtomicLevel，用于运行时动态调级
+	atomicLevel zap.AtomicLevel
 }
 
 var (
@@ -138,6 +171,8 @@ var (
 	mu  sync.Mutex
 )
 
+// New 根据opts构造logz默认的基于zap的Logger；
+// 编码、error日志分流、滚动、限流等具体构建细节见internal/backend/zap
 func New(opts *Options) *zapLogger {
 	if opts == nil {
 		opts = NewOptions()
@@ -147,47 +182,44 @@ func New(opts *Options) *zapLogger {
 		zapLevel = zapcore.InfoLevel
 	}
 
-	encodeLevel := zapcore.CapitalLevelEncoder
-	if opts.Format == consoleFormat && opts.EnableColor {
-		encodeLevel = zapcore.CapitalColorLevelEncoder
-	}
-
-	encodeConfig := &zapcore.EncoderConfig{
-		MessageKey:     "message",
-		LevelKey:       "level",
-		TimeKey:        "timestamp",
-		NameKey:        "logger",
-		CallerKey:      "	caller",
-		StacktraceKey:  "stacktrace",
-		LineEnding:     zapcore.DefaultLineEnding,
-		EncodeLevel:    encodeLevel,
-		EncodeTime:     timeEncoder,
-		EncodeDuration: milliSecondsDurationEncoder,
-		EncodeCaller:   zapcore.ShortCallerEncoder,
-	}
-
-	loggerConfig := &zap.Config{
-		Level:             zap.NewAtomicLevelAt(zapLevel),
+	l, atom, err := zapbackend.Build(zapbackend.Config{
+		Level:             zapLevel,
+		Name:              opts.Name,
+		OutputPaths:       opts.OutputPaths,
+		ErrorOutputPaths:  opts.ErrorOutputPaths,
+		ErrorLogPaths:     opts.ErrorLogPaths,
+		Format:            opts.Format,
+		ErrorFormat:       opts.ErrorFormat,
+		EnableColor:       opts.EnableColor,
+		ErrorEnableColor:  opts.ErrorEnableColor,
 		Development:       opts.Development,
-		DisableStacktrace: opts.DisableStacktrace,
 		DisableCaller:     opts.DisableCaller,
-		Sampling: &zap.SamplingConfig{
-			Initial:    100,
-			Thereafter: 100,
-		},
-		Encoding:         opts.Format,
-		EncoderConfig:    *encodeConfig,
-		OutputPaths:      opts.OutputPaths,
-		ErrorOutputPaths: opts.ErrorOutputPaths,
-	}
+		DisableStacktrace: opts.DisableStacktrace,
 
-	var err error
-	l, err := loggerConfig.Build(zap.AddStacktrace(zapcore.PanicLevel), zap.AddCallerSkip(1))
+		EnableRotate: opts.EnableRotate,
+		MaxSize:      opts.MaxSize,
+		MaxBackups:   opts.MaxBackups,
+		MaxAge:       opts.MaxAge,
+		Compress:     opts.Compress,
+		LocalTime:    opts.LocalTime,
+
+		SamplingEnabled:    opts.Sampling.Enabled,
+		SamplingInitial:    opts.Sampling.Initial,
+		SamplingThereafter: opts.Sampling.Thereafter,
+		SamplingTick:       opts.Sampling.Tick,
+
+		RateLimitEnabled: opts.RateLimit.Enabled,
+		RateLimitRate:    opts.RateLimit.Rate,
+		RateLimitBurst:   opts.RateLimit.Burst,
+		RateLimitMaxKeys: opts.RateLimit.MaxKeys,
+	})
 	if err != nil {
 		panic(err)
 	}
+
 	logger := &zapLogger{
-		zapLogger: l.Named(opts.Name),
+		zapLogger:   l,
+		atomicLevel: atom,
 		infoLogger: infoLogger{
 			log:   l,
 			level: zapcore.InfoLevel,
@@ -257,7 +289,7 @@ func WriteValue(keyAndValues ...interface{}) Logger {
 func (l *zapLogger) WriteValues(keyAndValues ...interface{}) Logger {
 	newLogger := l.zapLogger.With(handleFields(l.zapLogger, keyAndValues)...)
 
-	return NewLogger(newLogger)
+	return l.derive(newLogger)
 }
 func WithValue(keysAndValues ...interface{}) Logger {
 	return std.WithValue(keysAndValues...)
@@ -265,7 +297,7 @@ func WithValue(keysAndValues ...interface{}) Logger {
 func (l *zapLogger) WithValue(keysAndValues ...interface{}) Logger {
 	newLogger := l.zapLogger.With(handleFields(l.zapLogger, keysAndValues)...)
 
-	return NewLogger(newLogger)
+	return l.derive(newLogger)
 }
 
 func WithName(s string) Logger {
@@ -275,7 +307,21 @@ func WithName(s string) Logger {
 func (l *zapLogger) WithName(s string) Logger {
 	newLogger := l.zapLogger.Named(s)
 
-	return NewLogger(newLogger)
+	return l.derive(newLogger)
+}
+
+// derive 基于newZap构造一个新的zapLogger，复用l的atomicLevel，
+// 使得WithValue/WithName/WriteValues派生出的Logger与其源Logger共享同一个
+// 运行时调级开关：对源Logger调用SetLevel也会影响所有派生出的Logger
+func (l *zapLogger) derive(newZap *zap.Logger) Logger {
+	return &zapLogger{
+		zapLogger:   newZap,
+		atomicLevel: l.atomicLevel,
+		infoLogger: infoLogger{
+			level: zapcore.InfoLevel,
+			log:   newZap,
+		},
+	}
 }
 
 func Flush() {
@@ -286,10 +332,37 @@ func (l *zapLogger) Flush() {
 	_ = l.zapLogger.Sync()
 }
 
-// NewLogger create a new logz's Logger
+// SetLevel 调整全局std的日志级别，无需重启进程即可生效
+func SetLevel(lvl string) error {
+	return std.SetLevel(lvl)
+}
+
+// SetLevel 动态调整该Logger的日志级别，lvl取值与Options.Level一致（如"debug"、"info"）
+func (l *zapLogger) SetLevel(lvl string) error {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(lvl)); err != nil {
+		return err
+	}
+
+	l.atomicLevel.SetLevel(level)
+
+	return nil
+}
+
+// LevelHandler 返回zap.AtomicLevel自带的http.Handler，
+// 支持GET查看、PUT修改当前级别，例如：curl -XPUT -d '{"level":"debug"}' /log/level
+func (l *zapLogger) LevelHandler() http.Handler {
+	return l.atomicLevel
+}
+
+// NewLogger create a new logz's Logger.
+// 这是面向外部*zap.Logger的入口，拿不到原Logger的atomicLevel，因此会持有一个
+// 独立的AtomicLevel：对它调用SetLevel不会影响其他Logger，反之亦然。
+// 如果是从已有的logz Logger派生（WithValue/WithName等），请用derive以共享调级开关
 func NewLogger(l *zap.Logger) Logger {
 	return &zapLogger{
-		zapLogger: l,
+		zapLogger:   l,
+		atomicLevel: zap.NewAtomicLevel(),
 		infoLogger: infoLogger{
 			level: zapcore.InfoLevel,
 			log:   l,
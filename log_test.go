@@ -0,0 +1,73 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInfowNoNullKeyEntries(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.log")
+
+	opts := NewOptions()
+	opts.Format = jsonFormat
+	opts.OutputPaths = []string{path}
+
+	l := New(opts)
+	l.V(0).Infow("hello", "a", 1, "b", "two")
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+
+	if strings.Contains(string(data), `"":`) {
+		t.Fatalf("produced JSON contains a null/empty-key entry: %s", data)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(data), &m); err != nil {
+		t.Fatalf("output is not valid json: %v, got: %s", err, data)
+	}
+
+	if m["a"] != float64(1) {
+		t.Errorf("expected a=1, got %v", m["a"])
+	}
+	if m["b"] != "two" {
+		t.Errorf("expected b=two, got %v", m["b"])
+	}
+}
+
+func TestDerivedLoggerSharesAtomicLevel(t *testing.T) {
+	l := New(NewOptions())
+
+	derived := l.WithName("child").WithValue("k", "v")
+
+	if err := l.SetLevel("error"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+
+	if derived.(*zapLogger).atomicLevel.Level().String() != "error" {
+		t.Fatalf("expected derived logger's atomicLevel to be updated by the parent's SetLevel")
+	}
+}
+
+func BenchmarkInfow(b *testing.B) {
+	opts := NewOptions()
+	opts.Format = jsonFormat
+	opts.OutputPaths = []string{os.DevNull}
+
+	l := New(opts)
+	iv := l.V(0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iv.Infow("benchmark", "key1", i, "key2", "value")
+	}
+}
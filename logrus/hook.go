@@ -0,0 +1,57 @@
+// Package logrus 提供一个logrus.Hook，把已经在使用logrus打日志的代码转发给logz，
+// 从而复用logz的落盘、滚动、错误分流等能力，而不必重写调用点
+package logrus
+
+import (
+	"github.com/sirupsen/logrus"
+
+	log "github.com/liumingzhuo/logz"
+)
+
+// Hook 实现logrus.Hook，Fire时把entry转发给Logger
+type Hook struct {
+	// Logger 转发的目标，为空时使用log.FromContext(entry.Context)：
+	// entry的context中存有Logger时用它，否则使用logz包级别的std
+	Logger log.Logger
+}
+
+// NewHook 创建一个转发到logger的Hook；logger为nil时按entry.Context经log.FromContext取Logger，
+// context中没有Logger时使用logz的全局std
+func NewHook(logger log.Logger) *Hook {
+	return &Hook{Logger: logger}
+}
+
+// Levels 转发所有级别
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire 把entry.Data转换成keysAndValues后，按entry.Level转发给目标Logger
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	logger := h.Logger
+	if logger == nil {
+		logger = log.FromContext(entry.Context)
+	}
+
+	keysAndValues := make([]interface{}, 0, len(entry.Data)*2)
+	for k, v := range entry.Data {
+		keysAndValues = append(keysAndValues, k, v)
+	}
+
+	switch entry.Level {
+	case logrus.TraceLevel, logrus.DebugLevel:
+		logger.Debugw(entry.Message, keysAndValues...)
+	case logrus.InfoLevel:
+		logger.Infow(entry.Message, keysAndValues...)
+	case logrus.WarnLevel:
+		logger.Warnw(entry.Message, keysAndValues...)
+	case logrus.ErrorLevel:
+		logger.Errorw(entry.Message, keysAndValues...)
+	case logrus.FatalLevel:
+		logger.Fatalw(entry.Message, keysAndValues...)
+	case logrus.PanicLevel:
+		logger.Panicw(entry.Message, keysAndValues...)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package log
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	consoleFormat = "console"
+	jsonFormat    = "json"
+)
+
+// Options 包含与日志相关的配置项
+type Options struct {
+	OutputPaths       []string `json:"output-paths" mapstructure:"output-paths"`
+	ErrorOutputPaths  []string `json:"error-output-paths" mapstructure:"error-output-paths"`
+	Level             string   `json:"level" mapstructure:"level"`
+	Format            string   `json:"format" mapstructure:"format"`
+	DisableCaller     bool     `json:"disable-caller" mapstructure:"disable-caller"`
+	DisableStacktrace bool     `json:"disable-stacktrace" mapstructure:"disable-stacktrace"`
+	EnableColor       bool     `json:"enable-color" mapstructure:"enable-color"`
+	Development       bool     `json:"development" mapstructure:"development"`
+	Name              string   `json:"name" mapstructure:"name"`
+
+	// ErrorLogPaths 非空时开启错误日志分流：ErrorLevel及以上的日志改为写入这里指定的路径，
+	// OutputPaths则只接收低于ErrorLevel的日志，两者通过zapcore.NewTee组合
+	ErrorLogPaths []string `json:"error-log-paths" mapstructure:"error-log-paths"`
+	// ErrorFormat 错误日志sink使用的编码格式，为空时与Format保持一致
+	ErrorFormat string `json:"error-format" mapstructure:"error-format"`
+	// ErrorEnableColor 错误日志sink是否启用彩色level，默认与EnableColor一致
+	ErrorEnableColor bool `json:"error-enable-color" mapstructure:"error-enable-color"`
+
+	// Backend 选择底层日志实现，默认为"zap"。其余取值需要提前import对应的backend子包
+	// 以触发其init完成RegisterBackend注册，目前内置"slog"(backend/slog)、
+	// "logrus"(backend/logrus)、"klog"(backend/klog)
+	Backend string `json:"backend" mapstructure:"backend"`
+
+	// Sampling 控制zap原生的按等级采样策略，Enabled为false时完全关闭采样
+	Sampling SamplingOptions `json:"sampling" mapstructure:"sampling"`
+
+	// RateLimit 控制基于dedup_key字段的令牌桶限流，Enabled为false时不做任何限流
+	RateLimit RateLimitOptions `json:"rate-limit" mapstructure:"rate-limit"`
+
+	// EnableRotate 开启后，OutputPaths中的文件路径会被自动包装成按大小/时间滚动的lumberjack sink
+	EnableRotate bool `json:"enable-rotate" mapstructure:"enable-rotate"`
+	// MaxSize 单个日志文件的最大大小，单位MB，超出后触发切割
+	MaxSize int `json:"max-size" mapstructure:"max-size"`
+	// MaxBackups 最多保留的历史日志文件个数，0表示保留全部
+	MaxBackups int `json:"max-backups" mapstructure:"max-backups"`
+	// MaxAge 历史日志文件最多保留的天数，0表示不按时间清理
+	MaxAge int `json:"max-age" mapstructure:"max-age"`
+	// Compress 是否对切割后的历史日志文件进行gzip压缩
+	Compress bool `json:"compress" mapstructure:"compress"`
+	// LocalTime 切割文件名中的时间戳是否使用本地时间，默认使用UTC
+	LocalTime bool `json:"local-time" mapstructure:"local-time"`
+}
+
+// SamplingOptions 对应zap原生的按等级采样策略
+type SamplingOptions struct {
+	// Enabled 为false时不对日志做任何采样
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Initial 每个采样窗口（见Tick）内，同一条日志（message+level）前Initial条全部通过
+	Initial int `json:"initial" mapstructure:"initial"`
+	// Thereafter 超过Initial条之后，每Thereafter条才通过1条
+	Thereafter int `json:"thereafter" mapstructure:"thereafter"`
+	// Tick 采样窗口长度，仅在ErrorLogPaths分流（buildTeeLogger）场景下生效，
+	// 未分流场景复用zap.Config.Build()固定的1秒窗口
+	Tick time.Duration `json:"tick" mapstructure:"tick"`
+}
+
+// RateLimitOptions 控制按dedup_key字段聚合的令牌桶限流
+type RateLimitOptions struct {
+	// Enabled 为false时不做任何限流
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Rate 每个key每秒放行的日志条数
+	Rate float64 `json:"rate" mapstructure:"rate"`
+	// Burst 令牌桶容量，即短时间内允许的突发条数
+	Burst int `json:"burst" mapstructure:"burst"`
+	// MaxKeys 最多同时跟踪的key数量，超出后按LRU淘汰最久未使用的key
+	MaxKeys int `json:"max-keys" mapstructure:"max-keys"`
+}
+
+// NewOptions 创建一个默认配置的Options
+func NewOptions() *Options {
+	return &Options{
+		Level:             zapcore.InfoLevel.String(),
+		DisableCaller:     false,
+		DisableStacktrace: false,
+		Format:            consoleFormat,
+		EnableColor:       false,
+		Development:       false,
+		OutputPaths:       []string{"stdout"},
+		ErrorOutputPaths:  []string{"stderr"},
+
+		EnableRotate: false,
+		MaxSize:      100,
+		MaxBackups:   5,
+		MaxAge:       30,
+		Compress:     false,
+		LocalTime:    false,
+
+		Sampling: SamplingOptions{
+			Enabled:    true,
+			Initial:    100,
+			Thereafter: 100,
+			Tick:       time.Second,
+		},
+		RateLimit: RateLimitOptions{
+			Enabled: false,
+			Rate:    1,
+			Burst:   1,
+			MaxKeys: 1000,
+		},
+	}
+}